@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package policycheck performs local, syntactic validation of IAM policy
+// JSON documents before they are sent to the AWS Access Analyzer
+// ValidatePolicy API. It mirrors the checks the old AWS provider's
+// validateIAMPolicyJson performed on "policy" fields, which exist mainly
+// to turn AWS's opaque "MalformedPolicyDocument: policy failed legacy
+// parsing" error into something that points at the actual problem (most
+// commonly a HEREDOC-indented policy with leading whitespace or a stray
+// UTF-8 BOM).
+package policycheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// utf8BOM is the byte sequence of a UTF-8 byte order mark. IAM's JSON
+// parser rejects documents that begin with one.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// topLevelKeys are the only keys IAM recognizes at the document's root.
+var topLevelKeys = map[string]bool{
+	"Version":   true,
+	"Id":        true,
+	"Statement": true,
+}
+
+// allowedVersions are the Version strings IAM accepts.
+var allowedVersions = map[string]bool{
+	"2012-10-17": true,
+	"2008-10-17": true,
+}
+
+// IAMPolicyDocument is a minimal structural representation of an IAM
+// policy document, sufficient to confirm the document is well-formed
+// without re-implementing IAM's grammar.
+type IAMPolicyDocument struct {
+	Version   string            `json:"Version,omitempty"`
+	Id        string            `json:"Id,omitempty"`
+	Statement []json.RawMessage `json:"Statement"`
+}
+
+// statement is a minimal structural representation of one IAM policy
+// statement, sufficient to sanity-check it without re-implementing IAM's
+// grammar.
+type statement struct {
+	Sid          string          `json:"Sid,omitempty"`
+	Effect       string          `json:"Effect"`
+	Action       json.RawMessage `json:"Action,omitempty"`
+	NotAction    json.RawMessage `json:"NotAction,omitempty"`
+	Resource     json.RawMessage `json:"Resource,omitempty"`
+	NotResource  json.RawMessage `json:"NotResource,omitempty"`
+	Principal    json.RawMessage `json:"Principal,omitempty"`
+	NotPrincipal json.RawMessage `json:"NotPrincipal,omitempty"`
+	Condition    json.RawMessage `json:"Condition,omitempty"`
+}
+
+var statementKeys = map[string]bool{
+	"Sid":          true,
+	"Effect":       true,
+	"Action":       true,
+	"NotAction":    true,
+	"Resource":     true,
+	"NotResource":  true,
+	"Principal":    true,
+	"NotPrincipal": true,
+	"Condition":    true,
+}
+
+// Result is the outcome of a local validation pass over a policy
+// document.
+type Result struct {
+	// Errors are human-readable descriptions of the problems found.
+	Errors []string
+
+	// Document is the parsed policy document, or nil if the document was
+	// fatally malformed and could not be parsed at all.
+	Document *IAMPolicyDocument
+}
+
+// Fatal reports whether the document is malformed badly enough that
+// calling ValidatePolicy against it would be pointless.
+func (r Result) Fatal() bool {
+	return r.Document == nil
+}
+
+// CheckPolicyJSON runs the local structural checks against a policy JSON
+// string. The first few checks are fatal - a leading UTF-8 BOM, a first
+// non-whitespace byte that isn't '{', or a document that doesn't
+// unmarshal into an IAMPolicyDocument all leave Document nil, since
+// there's nothing left worth checking further. Once the document parses,
+// CheckPolicyJSON also flags non-fatal structural problems - unknown
+// top-level keys, an unrecognized Version, and per-statement sanity
+// issues (bad Effect, missing Action/Resource, duplicate Sids, malformed
+// principal shapes) - as additional Errors alongside a non-nil Document,
+// so offline validation still has something concrete to say even without
+// calling ValidatePolicy.
+func CheckPolicyJSON(policy string) Result {
+	var result Result
+
+	if bytes.HasPrefix([]byte(policy), utf8BOM) {
+		result.Errors = append(result.Errors, "policy document begins with a UTF-8 byte order mark (BOM), which AWS IAM rejects when parsing JSON policies")
+		return result
+	}
+
+	trimmed := strings.TrimSpace(policy)
+	if trimmed == "" {
+		result.Errors = append(result.Errors, "policy document is empty")
+		return result
+	}
+
+	if trimmed[0] != '{' {
+		result.Errors = append(result.Errors, "policy document must be a JSON object; the first non-whitespace character must be '{'")
+		return result
+	}
+
+	var doc IAMPolicyDocument
+	if err := json.Unmarshal([]byte(trimmed), &doc); err != nil {
+		result.Errors = append(result.Errors, "policy document is not valid JSON: "+err.Error())
+		return result
+	}
+	result.Document = &doc
+
+	var rawTopLevel map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(trimmed), &rawTopLevel); err == nil {
+		for key := range rawTopLevel {
+			if !topLevelKeys[key] {
+				result.Errors = append(result.Errors, fmt.Sprintf("unrecognized top-level key %q", key))
+			}
+		}
+	}
+
+	if doc.Version != "" && !allowedVersions[doc.Version] {
+		result.Errors = append(result.Errors, fmt.Sprintf("Version %q is not one of the allowed values (2012-10-17, 2008-10-17)", doc.Version))
+	}
+
+	result.Errors = append(result.Errors, checkStatements(doc.Statement)...)
+
+	return result
+}
+
+// checkStatements sanity-checks every statement in a policy document:
+// unknown keys, Effect must be Allow/Deny, at least one of
+// Action/NotAction must be present, at least one of Resource/NotResource/
+// Principal/NotPrincipal must be present, Sids must be unique, and any
+// Principal/NotPrincipal must have a recognized shape.
+func checkStatements(statements []json.RawMessage) []string {
+	var errs []string
+	seenSids := map[string]bool{}
+
+	for i, raw := range statements {
+		label := fmt.Sprintf("Statement[%d]", i)
+
+		var rawFields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &rawFields); err != nil {
+			errs = append(errs, fmt.Sprintf("%s is not a JSON object", label))
+			continue
+		}
+		for key := range rawFields {
+			if !statementKeys[key] {
+				errs = append(errs, fmt.Sprintf("%s has an unrecognized key %q", label, key))
+			}
+		}
+
+		var s statement
+		if err := json.Unmarshal(raw, &s); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", label, err.Error()))
+			continue
+		}
+
+		if s.Sid != "" {
+			label = fmt.Sprintf("Statement[%d] (Sid %q)", i, s.Sid)
+			if seenSids[s.Sid] {
+				errs = append(errs, fmt.Sprintf("duplicate Sid %q", s.Sid))
+			}
+			seenSids[s.Sid] = true
+		}
+
+		if s.Effect != "Allow" && s.Effect != "Deny" {
+			errs = append(errs, fmt.Sprintf("%s has Effect %q, must be \"Allow\" or \"Deny\"", label, s.Effect))
+		}
+
+		if len(s.Action) == 0 && len(s.NotAction) == 0 {
+			errs = append(errs, fmt.Sprintf("%s must have an Action or NotAction", label))
+		}
+		if len(s.Resource) == 0 && len(s.NotResource) == 0 && len(s.Principal) == 0 && len(s.NotPrincipal) == 0 {
+			errs = append(errs, fmt.Sprintf("%s must have a Resource, NotResource, Principal, or NotPrincipal", label))
+		}
+
+		if err := checkPrincipalShape(s.Principal); err != nil {
+			errs = append(errs, fmt.Sprintf("%s has a malformed Principal: %s", label, err.Error()))
+		}
+		if err := checkPrincipalShape(s.NotPrincipal); err != nil {
+			errs = append(errs, fmt.Sprintf("%s has a malformed NotPrincipal: %s", label, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+// checkPrincipalShape confirms raw is either the literal string "*" or a
+// JSON object whose values are each a string or an array of strings, the
+// only shapes IAM accepts for Principal/NotPrincipal.
+func checkPrincipalShape(raw json.RawMessage) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var wildcard string
+	if err := json.Unmarshal(raw, &wildcard); err == nil {
+		if wildcard != "*" {
+			return fmt.Errorf("a string principal must be \"*\", got %q", wildcard)
+		}
+		return nil
+	}
+
+	var byType map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &byType); err != nil {
+		return fmt.Errorf("must be the string \"*\" or an object mapping principal type to identifiers")
+	}
+
+	for principalType, value := range byType {
+		var single string
+		if json.Unmarshal(value, &single) == nil {
+			continue
+		}
+		var multiple []string
+		if json.Unmarshal(value, &multiple) == nil {
+			continue
+		}
+		return fmt.Errorf("principal type %q must be a string or a list of strings", principalType)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policycheck
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPolicyJSON_Fatal(t *testing.T) {
+	cases := map[string]struct {
+		policy      string
+		wantErrPart string
+	}{
+		"utf8 bom": {
+			policy:      "\xEF\xBB\xBF" + `{"Version":"2012-10-17","Statement":[]}`,
+			wantErrPart: "byte order mark",
+		},
+		"empty": {
+			policy:      "   ",
+			wantErrPart: "empty",
+		},
+		"not json": {
+			policy:      `{not valid json`,
+			wantErrPart: "not valid JSON",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := CheckPolicyJSON(tc.policy)
+			if !result.Fatal() {
+				t.Fatalf("expected Fatal() to be true, got false (errors: %v)", result.Errors)
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("expected exactly one error, got %v", result.Errors)
+			}
+			if !strings.Contains(result.Errors[0], tc.wantErrPart) {
+				t.Errorf("error %q does not contain %q", result.Errors[0], tc.wantErrPart)
+			}
+		})
+	}
+}
+
+func TestCheckPolicyJSON_NonFatal(t *testing.T) {
+	cases := map[string]struct {
+		policy      string
+		wantErrPart string
+	}{
+		"unknown top-level key": {
+			policy:      `{"Version":"2012-10-17","Statement":[],"Foo":"bar"}`,
+			wantErrPart: `unrecognized top-level key "Foo"`,
+		},
+		"unrecognized version": {
+			policy:      `{"Version":"2024-01-01","Statement":[]}`,
+			wantErrPart: `Version "2024-01-01" is not one of the allowed values`,
+		},
+		"duplicate sid": {
+			policy: `{"Version":"2012-10-17","Statement":[
+				{"Sid":"A","Effect":"Allow","Action":"s3:GetObject","Resource":"*"},
+				{"Sid":"A","Effect":"Allow","Action":"s3:PutObject","Resource":"*"}
+			]}`,
+			wantErrPart: `duplicate Sid "A"`,
+		},
+		"bad effect": {
+			policy:      `{"Version":"2012-10-17","Statement":[{"Effect":"Maybe","Action":"s3:GetObject","Resource":"*"}]}`,
+			wantErrPart: `must be "Allow" or "Deny"`,
+		},
+		"missing action and not action": {
+			policy:      `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Resource":"*"}]}`,
+			wantErrPart: "must have an Action or NotAction",
+		},
+		"missing resource, not resource, and principal": {
+			policy:      `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject"}]}`,
+			wantErrPart: "must have a Resource, NotResource, Principal, or NotPrincipal",
+		},
+		"unrecognized statement key": {
+			policy:      `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*","Foo":"bar"}]}`,
+			wantErrPart: `unrecognized key "Foo"`,
+		},
+		"malformed string principal": {
+			policy:      `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Principal":"everyone"}]}`,
+			wantErrPart: `a string principal must be "*"`,
+		},
+		"malformed principal type value": {
+			policy:      `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Principal":{"AWS":123}}]}`,
+			wantErrPart: `principal type "AWS" must be a string or a list of strings`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result := CheckPolicyJSON(tc.policy)
+			if result.Fatal() {
+				t.Fatalf("expected Fatal() to be false, got true (errors: %v)", result.Errors)
+			}
+
+			found := false
+			for _, err := range result.Errors {
+				if strings.Contains(err, tc.wantErrPart) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("expected an error containing %q, got %v", tc.wantErrPart, result.Errors)
+			}
+		})
+	}
+}
+
+func TestCheckPolicyJSON_ValidDocument(t *testing.T) {
+	policy := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Sid": "AllowGet",
+				"Effect": "Allow",
+				"Action": "s3:GetObject",
+				"Resource": "*"
+			},
+			{
+				"Sid": "AllowAssume",
+				"Effect": "Allow",
+				"Action": "sts:AssumeRole",
+				"Principal": {"AWS": ["arn:aws:iam::123456789012:root"]}
+			},
+			{
+				"Effect": "Allow",
+				"Action": "s3:GetObject",
+				"Principal": "*"
+			}
+		]
+	}`
+
+	result := CheckPolicyJSON(policy)
+	if result.Fatal() {
+		t.Fatalf("expected Fatal() to be false, got true (errors: %v)", result.Errors)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("expected no errors for a well-formed policy, got %v", result.Errors)
+	}
+	if result.Document == nil || len(result.Document.Statement) != 3 {
+		t.Errorf("expected 3 parsed statements, got %+v", result.Document)
+	}
+}
+
+func TestCheckPolicyJSON_LeadingWhitespaceIsTrimmed(t *testing.T) {
+	policy := "\n    " + `{"Version":"2012-10-17","Statement":[]}`
+
+	result := CheckPolicyJSON(policy)
+	if result.Fatal() {
+		t.Fatalf("expected Fatal() to be false for leading whitespace before a valid document, got true (errors: %v)", result.Errors)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("expected no errors, got %v", result.Errors)
+	}
+}
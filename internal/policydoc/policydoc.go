@@ -0,0 +1,396 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package policydoc assembles IAM policy JSON documents from structured
+// statement inputs, modeled on the ergonomics of the familiar
+// aws_iam_policy_document data source: statements keyed by Sid, merging
+// of source_policy_documents and override_policy_documents, and the
+// `&{var}` escape for IAM policy variables that would otherwise collide
+// with Terraform's own `${...}` interpolation syntax.
+package policydoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Condition is a single IAM policy condition operator, e.g.
+// { Test = "StringEquals", Variable = "aws:username", Values = [...] }.
+type Condition struct {
+	Test     string   `json:"-"`
+	Variable string   `json:"-"`
+	Values   []string `json:"-"`
+}
+
+// Principal is a single IAM principal block, e.g. { Type = "Service",
+// Identifiers = ["ec2.amazonaws.com"] }.
+type Principal struct {
+	Type        string   `json:"-"`
+	Identifiers []string `json:"-"`
+}
+
+// Statement is one Statement entry in an IAM policy document.
+type Statement struct {
+	Sid           string      `json:"-"`
+	Effect        string      `json:"-"` // "Allow" or "Deny"; defaults to "Allow".
+	Actions       []string    `json:"-"`
+	NotActions    []string    `json:"-"`
+	Resources     []string    `json:"-"`
+	NotResources  []string    `json:"-"`
+	Principals    []Principal `json:"-"`
+	NotPrincipals []Principal `json:"-"`
+	Conditions    []Condition `json:"-"`
+}
+
+// Document is a full IAM policy document: a version, an optional Id, and
+// an ordered list of statements.
+type Document struct {
+	Version   string      `json:"-"`
+	Id        string      `json:"-"`
+	Statement []Statement `json:"-"`
+}
+
+// EscapeInterpolation rewrites the `&{var}` escape hatch used in HCL
+// statement inputs into the literal `${var}` that IAM policy variables
+// require, so that policy variables like `${aws:username}` don't get
+// mistaken for Terraform interpolation sequences while the document is
+// being authored.
+func EscapeInterpolation(s string) string {
+	return strings.ReplaceAll(s, "&{", "${")
+}
+
+// MergeStatementsBySid merges statement lists in order: statements
+// sharing a non-empty Sid are replaced by the later occurrence, and
+// statements are otherwise appended in the order they're first seen.
+// Statements with an empty Sid are never merged; they are always
+// appended as-is.
+func MergeStatementsBySid(lists ...[]Statement) []Statement {
+	order := []string{}
+	bySid := map[string]Statement{}
+	anonymous := []Statement{}
+
+	for _, list := range lists {
+		for _, stmt := range list {
+			if stmt.Sid == "" {
+				anonymous = append(anonymous, stmt)
+				continue
+			}
+			if _, ok := bySid[stmt.Sid]; !ok {
+				order = append(order, stmt.Sid)
+			}
+			bySid[stmt.Sid] = stmt
+		}
+	}
+
+	merged := make([]Statement, 0, len(order)+len(anonymous))
+	for _, sid := range order {
+		merged = append(merged, bySid[sid])
+	}
+	merged = append(merged, anonymous...)
+	return merged
+}
+
+// reverseStatements returns a copy of lists with their order reversed,
+// used to give override_policy_documents the opposite merge precedence
+// from source_policy_documents: within an override list, earlier
+// documents win over later ones.
+func reverseStatements(lists [][]Statement) [][]Statement {
+	reversed := make([][]Statement, len(lists))
+	for i, list := range lists {
+		reversed[len(lists)-1-i] = list
+	}
+	return reversed
+}
+
+// Build assembles the final statement list for a document: source
+// documents are merged by Sid (later document in the list wins), this
+// document's own statements are layered on top of that (and win ties),
+// and finally override documents are merged by Sid with the opposite
+// precedence (earlier document in the list wins) and take priority over
+// everything else.
+func Build(statements []Statement, sourceDocs [][]Statement, overrideDocs [][]Statement) []Statement {
+	merged := MergeStatementsBySid(sourceDocs...)
+	merged = MergeStatementsBySid(merged, statements)
+
+	if len(overrideDocs) > 0 {
+		overrideMerged := MergeStatementsBySid(reverseStatements(overrideDocs)...)
+		merged = MergeStatementsBySid(merged, overrideMerged)
+	}
+
+	return merged
+}
+
+// Render marshals a Document to its canonical IAM policy JSON
+// representation.
+func Render(doc Document) (string, error) {
+	out := renderedDocument{
+		Version:   doc.Version,
+		Id:        doc.Id,
+		Statement: make([]renderedStatement, 0, len(doc.Statement)),
+	}
+	if out.Version == "" {
+		out.Version = "2012-10-17"
+	}
+
+	for _, stmt := range doc.Statement {
+		out.Statement = append(out.Statement, renderStatement(stmt))
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return "", err
+	}
+	return EscapeInterpolation(strings.TrimSuffix(buf.String(), "\n")), nil
+}
+
+// renderedDocument and renderedStatement mirror the PascalCase shape IAM
+// expects on the wire; Statement fields are normalized (single-element
+// lists collapse to a bare string, empty lists are omitted) to match the
+// output of aws_iam_policy_document.
+type renderedDocument struct {
+	Version   string              `json:"Version"`
+	Id        string              `json:"Id,omitempty"`
+	Statement []renderedStatement `json:"Statement"`
+}
+
+type renderedStatement struct {
+	Sid          string      `json:"Sid,omitempty"`
+	Effect       string      `json:"Effect"`
+	Principal    interface{} `json:"Principal,omitempty"`
+	NotPrincipal interface{} `json:"NotPrincipal,omitempty"`
+	Action       interface{} `json:"Action,omitempty"`
+	NotAction    interface{} `json:"NotAction,omitempty"`
+	Resource     interface{} `json:"Resource,omitempty"`
+	NotResource  interface{} `json:"NotResource,omitempty"`
+	Condition    interface{} `json:"Condition,omitempty"`
+}
+
+func renderStatement(stmt Statement) renderedStatement {
+	effect := stmt.Effect
+	if effect == "" {
+		effect = "Allow"
+	}
+
+	return renderedStatement{
+		Sid:          stmt.Sid,
+		Effect:       effect,
+		Principal:    renderPrincipals(stmt.Principals),
+		NotPrincipal: renderPrincipals(stmt.NotPrincipals),
+		Action:       stringOrSlice(stmt.Actions),
+		NotAction:    stringOrSlice(stmt.NotActions),
+		Resource:     stringOrSlice(stmt.Resources),
+		NotResource:  stringOrSlice(stmt.NotResources),
+		Condition:    renderConditions(stmt.Conditions),
+	}
+}
+
+// stringOrSlice renders a single-element list as a bare string and an
+// empty list as nil (so it's omitted entirely), matching IAM's usual
+// policy shape.
+func stringOrSlice(vals []string) interface{} {
+	switch len(vals) {
+	case 0:
+		return nil
+	case 1:
+		return vals[0]
+	default:
+		return vals
+	}
+}
+
+// renderPrincipals renders a Principal list into IAM's
+// { "Type": "Identifier" | ["Identifier", ...] } shape, or the bare "*"
+// wildcard for the "anyone" principal.
+func renderPrincipals(principals []Principal) interface{} {
+	if len(principals) == 0 {
+		return nil
+	}
+	if len(principals) == 1 && principals[0].Type == "*" {
+		return "*"
+	}
+
+	out := map[string]interface{}{}
+	for _, p := range principals {
+		out[p.Type] = stringOrSlice(p.Identifiers)
+	}
+	return out
+}
+
+// renderConditions renders a Condition list into IAM's
+// { "Test": { "Variable": [Values...] } } shape, grouped by Test.
+func renderConditions(conditions []Condition) interface{} {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	out := map[string]map[string]interface{}{}
+	tests := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		if _, ok := out[c.Test]; !ok {
+			out[c.Test] = map[string]interface{}{}
+			tests = append(tests, c.Test)
+		}
+		out[c.Test][c.Variable] = stringOrSlice(c.Values)
+	}
+
+	sort.Strings(tests)
+	return out
+}
+
+// rawStatement and rawDocument mirror the wire shape of an IAM policy
+// document closely enough to unmarshal it, deferring the
+// string-or-array normalization of Action/Resource/Principal/Condition
+// to ParseStatements.
+type rawStatement struct {
+	Sid          string          `json:"Sid,omitempty"`
+	Effect       string          `json:"Effect"`
+	Principal    json.RawMessage `json:"Principal,omitempty"`
+	NotPrincipal json.RawMessage `json:"NotPrincipal,omitempty"`
+	Action       json.RawMessage `json:"Action,omitempty"`
+	NotAction    json.RawMessage `json:"NotAction,omitempty"`
+	Resource     json.RawMessage `json:"Resource,omitempty"`
+	NotResource  json.RawMessage `json:"NotResource,omitempty"`
+	Condition    json.RawMessage `json:"Condition,omitempty"`
+}
+
+type rawDocument struct {
+	Version   string         `json:"Version"`
+	Id        string         `json:"Id,omitempty"`
+	Statement []rawStatement `json:"Statement"`
+}
+
+// ParseStatements unmarshals a rendered IAM policy JSON document (as
+// produced by Render, or any hand-written policy JSON) back into a
+// []Statement, so that source_policy_documents and
+// override_policy_documents can be merged with a document being built.
+func ParseStatements(policyJSON string) ([]Statement, error) {
+	var raw rawDocument
+	if err := json.Unmarshal([]byte(policyJSON), &raw); err != nil {
+		return nil, err
+	}
+
+	stmts := make([]Statement, 0, len(raw.Statement))
+	for _, r := range raw.Statement {
+		stmt := Statement{Sid: r.Sid, Effect: r.Effect}
+
+		var err error
+		if stmt.Actions, err = parseStringOrSlice(r.Action); err != nil {
+			return nil, err
+		}
+		if stmt.NotActions, err = parseStringOrSlice(r.NotAction); err != nil {
+			return nil, err
+		}
+		if stmt.Resources, err = parseStringOrSlice(r.Resource); err != nil {
+			return nil, err
+		}
+		if stmt.NotResources, err = parseStringOrSlice(r.NotResource); err != nil {
+			return nil, err
+		}
+		if stmt.Principals, err = parsePrincipals(r.Principal); err != nil {
+			return nil, err
+		}
+		if stmt.NotPrincipals, err = parsePrincipals(r.NotPrincipal); err != nil {
+			return nil, err
+		}
+		if stmt.Conditions, err = parseConditions(r.Condition); err != nil {
+			return nil, err
+		}
+
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+// parseStringOrSlice accepts either a bare JSON string or a JSON array of
+// strings, mirroring the shapes stringOrSlice can produce.
+func parseStringOrSlice(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}, nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// parsePrincipals accepts either the bare "*" wildcard or a
+// { "Type": "Identifier" | ["Identifier", ...] } object.
+func parsePrincipals(raw json.RawMessage) ([]Principal, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var wildcard string
+	if err := json.Unmarshal(raw, &wildcard); err == nil {
+		return []Principal{{Type: wildcard}}, nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+
+	types := make([]string, 0, len(m))
+	for t := range m {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	principals := make([]Principal, 0, len(types))
+	for _, t := range types {
+		ids, err := parseStringOrSlice(m[t])
+		if err != nil {
+			return nil, err
+		}
+		principals = append(principals, Principal{Type: t, Identifiers: ids})
+	}
+	return principals, nil
+}
+
+// parseConditions accepts IAM's { "Test": { "Variable": Values } } shape.
+func parseConditions(raw json.RawMessage) ([]Condition, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var m map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+
+	tests := make([]string, 0, len(m))
+	for t := range m {
+		tests = append(tests, t)
+	}
+	sort.Strings(tests)
+
+	conditions := []Condition{}
+	for _, test := range tests {
+		vars := make([]string, 0, len(m[test]))
+		for v := range m[test] {
+			vars = append(vars, v)
+		}
+		sort.Strings(vars)
+
+		for _, v := range vars {
+			values, err := parseStringOrSlice(m[test][v])
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, Condition{Test: test, Variable: v, Values: values})
+		}
+	}
+	return conditions, nil
+}
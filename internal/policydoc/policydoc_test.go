@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policydoc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEscapeInterpolation(t *testing.T) {
+	cases := map[string]struct {
+		in   string
+		want string
+	}{
+		"no variables":  {in: "arn:aws:s3:::my-bucket/*", want: "arn:aws:s3:::my-bucket/*"},
+		"one variable":  {in: "arn:aws:s3:::my-bucket/&{aws:username}/*", want: "arn:aws:s3:::my-bucket/${aws:username}/*"},
+		"two variables": {in: "&{aws:username}-&{aws:userid}", want: "${aws:username}-${aws:userid}"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeInterpolation(tc.in); got != tc.want {
+				t.Errorf("EscapeInterpolation(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeStatementsBySid(t *testing.T) {
+	first := []Statement{
+		{Sid: "A", Actions: []string{"first-a"}},
+		{Actions: []string{"anon-1"}},
+	}
+	second := []Statement{
+		{Sid: "A", Actions: []string{"second-a"}},
+		{Sid: "B", Actions: []string{"second-b"}},
+		{Actions: []string{"anon-2"}},
+	}
+
+	merged := MergeStatementsBySid(first, second)
+
+	bySid := map[string]Statement{}
+	var anonymous []Statement
+	for _, stmt := range merged {
+		if stmt.Sid == "" {
+			anonymous = append(anonymous, stmt)
+			continue
+		}
+		bySid[stmt.Sid] = stmt
+	}
+
+	if got := bySid["A"].Actions; !reflect.DeepEqual(got, []string{"second-a"}) {
+		t.Errorf("Sid A: later list should win, got Actions %v", got)
+	}
+	if got := bySid["B"].Actions; !reflect.DeepEqual(got, []string{"second-b"}) {
+		t.Errorf("Sid B: got Actions %v, want [second-b]", got)
+	}
+	if len(anonymous) != 2 {
+		t.Fatalf("expected 2 anonymous statements to be preserved, got %d: %v", len(anonymous), anonymous)
+	}
+}
+
+func TestBuild_SourceAndOverridePrecedence(t *testing.T) {
+	current := []Statement{{Sid: "S", Actions: []string{"current"}}}
+
+	sourceDocs := [][]Statement{
+		{{Sid: "S", Actions: []string{"source1"}}},
+		{{Sid: "S", Actions: []string{"source2"}}},
+	}
+	overrideDocs := [][]Statement{
+		{{Sid: "S", Actions: []string{"overrideA"}}},
+		{{Sid: "S", Actions: []string{"overrideB"}}},
+	}
+
+	t.Run("current statement wins over all source documents", func(t *testing.T) {
+		result := Build(current, sourceDocs, nil)
+		if len(result) != 1 {
+			t.Fatalf("expected 1 merged statement, got %d: %v", len(result), result)
+		}
+		if got := result[0].Actions; !reflect.DeepEqual(got, []string{"current"}) {
+			t.Errorf("got Actions %v, want [current] (this document's own statement should beat every source doc)", got)
+		}
+	})
+
+	t.Run("earlier override document wins, opposite of source precedence", func(t *testing.T) {
+		result := Build(current, sourceDocs, overrideDocs)
+		if len(result) != 1 {
+			t.Fatalf("expected 1 merged statement, got %d: %v", len(result), result)
+		}
+		if got := result[0].Actions; !reflect.DeepEqual(got, []string{"overrideA"}) {
+			t.Errorf("got Actions %v, want [overrideA] (earlier override doc should win and override everything else)", got)
+		}
+	})
+}
+
+func TestRenderParseStatements_RoundTrip(t *testing.T) {
+	statements := []Statement{
+		{
+			Sid:       "AllowGet",
+			Effect:    "Allow",
+			Actions:   []string{"s3:GetObject", "s3:PutObject"},
+			Resources: []string{"arn:aws:s3:::my-bucket/&{aws:username}/*"},
+			Principals: []Principal{
+				{Type: "AWS", Identifiers: []string{"arn:aws:iam::111111111111:root"}},
+			},
+			Conditions: []Condition{
+				{Test: "StringEquals", Variable: "aws:username", Values: []string{"alice"}},
+			},
+		},
+		{
+			Effect:     "Allow",
+			Actions:    []string{"sts:AssumeRole"},
+			Principals: []Principal{{Type: "*"}},
+		},
+	}
+
+	rendered, err := Render(Document{Statement: statements})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	if strings.Contains(rendered, "&{") {
+		t.Errorf("rendered policy still contains an unescaped &{ sequence: %s", rendered)
+	}
+	if !strings.Contains(rendered, "${aws:username}") {
+		t.Errorf("rendered policy should contain the escaped ${aws:username} variable: %s", rendered)
+	}
+
+	parsed, err := ParseStatements(rendered)
+	if err != nil {
+		t.Fatalf("ParseStatements: %v", err)
+	}
+	if len(parsed) != 2 {
+		t.Fatalf("expected 2 parsed statements, got %d: %+v", len(parsed), parsed)
+	}
+
+	want := Statement{
+		Sid:       "AllowGet",
+		Effect:    "Allow",
+		Actions:   []string{"s3:GetObject", "s3:PutObject"},
+		Resources: []string{"arn:aws:s3:::my-bucket/${aws:username}/*"},
+		Principals: []Principal{
+			{Type: "AWS", Identifiers: []string{"arn:aws:iam::111111111111:root"}},
+		},
+		Conditions: []Condition{
+			{Test: "StringEquals", Variable: "aws:username", Values: []string{"alice"}},
+		},
+	}
+	if !reflect.DeepEqual(parsed[0], want) {
+		t.Errorf("round-tripped statement mismatch\ngot:  %+v\nwant: %+v", parsed[0], want)
+	}
+
+	if got := parsed[1].Principals; !reflect.DeepEqual(got, []Principal{{Type: "*"}}) {
+		t.Errorf("wildcard principal round-trip mismatch, got %+v", got)
+	}
+}
+
+func TestParseStatements_InvalidJSON(t *testing.T) {
+	if _, err := ParseStatements("not json"); err == nil {
+		t.Error("expected an error parsing invalid JSON, got nil")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sync"
+)
+
+// batchWorkerLimit bounds how many ValidatePolicy calls run concurrently
+// when validating a batch of policies, so a large list doesn't fan out
+// into hundreds of simultaneous AWS API calls.
+const batchWorkerLimit = 8
+
+// PolicyInput is one entry of the validate_policies list(object({ name,
+// policy_json, policy_type, resource_type })) argument.
+type PolicyInput struct {
+	Name         string `tfsdk:"name"`
+	PolicyJSON   string `tfsdk:"policy_json"`
+	PolicyType   string `tfsdk:"policy_type"`
+	ResourceType string `tfsdk:"resource_type"`
+}
+
+// PolicyResult is the per-policy outcome of a batch validation, keyed by
+// PolicyInput.Name in the map validate_policies returns.
+type PolicyResult struct {
+	Findings    []Finding `tfsdk:"findings"`
+	LocalErrors []string  `tfsdk:"local_errors"`
+	Error       string    `tfsdk:"error"`
+}
+
+// batchValidatePolicies runs runValidatePolicy for every policy across a
+// bounded worker pool, filtering each policy's findings to minSeverity.
+// It stops launching new work as soon as ctx is cancelled (e.g. on a
+// Terraform interrupt), recording the cancellation as that policy's
+// error instead of starting it.
+func batchValidatePolicies(ctx context.Context, data ProviderData, policies []PolicyInput, minSeverity string, skipRemote bool) map[string]PolicyResult {
+	results := make(map[string]PolicyResult, len(policies))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchWorkerLimit)
+
+	for _, p := range policies {
+		if err := ctx.Err(); err != nil {
+			mu.Lock()
+			results[p.Name] = PolicyResult{Error: err.Error()}
+			mu.Unlock()
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			results[p.Name] = PolicyResult{Error: ctx.Err().Error()}
+			mu.Unlock()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(p PolicyInput) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var res PolicyResult
+			result, usageErr, awsErr := runValidatePolicy(ctx, data, p.PolicyJSON, p.PolicyType, p.ResourceType, skipRemote)
+			switch {
+			case usageErr != nil:
+				res.Error = usageErr.Error()
+			case awsErr != nil:
+				res.Error = awsErr.Error()
+			default:
+				res.Findings = filterFindingsBySeverity(result.Findings, minSeverity)
+				res.LocalErrors = result.LocalErrors
+			}
+
+			mu.Lock()
+			results[p.Name] = res
+			mu.Unlock()
+		}(p)
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/JosiahOne/terraform-provider-aws-iam-validator/internal/policydoc"
+)
+
+var _ datasource.DataSource = &PolicyDocumentDataSource{}
+var _ datasource.DataSourceWithConfigure = &PolicyDocumentDataSource{}
+
+// PolicyDocumentDataSource implements datasource.DataSource. It builds an
+// IAM policy JSON document from structured statement inputs (modeled on
+// aws_iam_policy_document) and then validates the result through the
+// same local+remote path as ValidatePolicyDataSource, so building and
+// validating a policy happen atomically.
+type PolicyDocumentDataSource struct {
+	data ProviderData
+}
+
+func NewPolicyDocumentDataSource() datasource.DataSource {
+	return &PolicyDocumentDataSource{}
+}
+
+func (d *PolicyDocumentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "aws-iam-validator_policy_document"
+}
+
+func (d *PolicyDocumentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, ok := providerDataFromConfigureRequest(req, resp)
+	if !ok {
+		return
+	}
+	d.data = data
+}
+
+func (d *PolicyDocumentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assembles an IAM policy document from structured statements and validates it using the AWS Access Analyzer ValidatePolicy API.",
+		Attributes: map[string]schema.Attribute{
+			"policy_type": schema.StringAttribute{
+				Description: "The type of policy to validate. One of IDENTITY_POLICY, RESOURCE_POLICY, or SERVICE_CONTROL_POLICY. Defaults to IDENTITY_POLICY.",
+				Optional:    true,
+			},
+			"resource_type": schema.StringAttribute{
+				Description: "The resource type the policy applies to, e.g. AWS::S3::Bucket, AWS::KMS::Key, or AWS::IAM::AssumeRolePolicyDocument. Only used when policy_type is RESOURCE_POLICY.",
+				Optional:    true,
+			},
+			"source_policy_documents": schema.ListAttribute{
+				Description: "List of IAM policy JSON documents to merge into this one. Statements are matched by Sid; of two source documents sharing a Sid, the later one in the list wins. This document's own statements take precedence over all source documents.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"override_policy_documents": schema.ListAttribute{
+				Description: "List of IAM policy JSON documents whose statements override this document (and its source documents) by Sid. Unlike source_policy_documents, of two override documents sharing a Sid the earlier one in the list wins.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"json": schema.StringAttribute{
+				Description: "The rendered IAM policy JSON document.",
+				Computed:    true,
+			},
+			"findings": findingsSchemaAttribute("List of findings from the AWS ValidatePolicy API."),
+			"local_errors": schema.ListAttribute{
+				Description: "Structural problems found by local, client-side validation before calling AWS. If this is non-empty and the document could not be parsed at all, ValidatePolicy is not called and findings will be empty.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"skip_remote": schema.BoolAttribute{
+				Description: "Skip calling ValidatePolicy and return only local findings. Also true whenever the provider is configured with offline = true.",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"statement": schema.ListNestedBlock{
+				Description: "A statement to include in the policy document.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"sid": schema.StringAttribute{
+							Description: "Statement identifier, used to merge statements from source/override documents.",
+							Optional:    true,
+						},
+						"effect": schema.StringAttribute{
+							Description: "Allow or Deny. Defaults to Allow.",
+							Optional:    true,
+						},
+						"actions": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"not_actions": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"resources": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"not_resources": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"principals":     principalsBlock(),
+						"not_principals": principalsBlock(),
+						"condition": schema.ListNestedBlock{
+							Description: "A condition that must be satisfied for the statement to apply.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"test": schema.StringAttribute{
+										Description: "Condition operator, e.g. StringEquals.",
+										Required:    true,
+									},
+									"variable": schema.StringAttribute{
+										Description: "Condition key, e.g. aws:username.",
+										Required:    true,
+									},
+									"values": schema.ListAttribute{
+										Description: "Values to compare the condition key against.",
+										Required:    true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// principalsBlock is shared by the "principals" and "not_principals"
+// blocks, which have identical shapes.
+func principalsBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "A principal block. Use type \"*\" with no identifiers for the anyone principal.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Description: "Principal type, e.g. AWS, Service, Federated, CanonicalUser, or *.",
+					Required:    true,
+				},
+				"identifiers": schema.ListAttribute{
+					Description: "Identifiers for this principal type, e.g. service principal names or ARNs.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+			},
+		},
+	}
+}
+
+type conditionModel struct {
+	Test     string   `tfsdk:"test"`
+	Variable string   `tfsdk:"variable"`
+	Values   []string `tfsdk:"values"`
+}
+
+type principalModel struct {
+	Type        string   `tfsdk:"type"`
+	Identifiers []string `tfsdk:"identifiers"`
+}
+
+type statementModel struct {
+	Sid           string           `tfsdk:"sid"`
+	Effect        string           `tfsdk:"effect"`
+	Actions       []string         `tfsdk:"actions"`
+	NotActions    []string         `tfsdk:"not_actions"`
+	Resources     []string         `tfsdk:"resources"`
+	NotResources  []string         `tfsdk:"not_resources"`
+	Principals    []principalModel `tfsdk:"principals"`
+	NotPrincipals []principalModel `tfsdk:"not_principals"`
+	Condition     []conditionModel `tfsdk:"condition"`
+}
+
+// toPolicyDoc converts a statementModel into the policydoc representation
+// used to build and render the document.
+func (s statementModel) toPolicyDoc() policydoc.Statement {
+	return policydoc.Statement{
+		Sid:           s.Sid,
+		Effect:        s.Effect,
+		Actions:       s.Actions,
+		NotActions:    s.NotActions,
+		Resources:     s.Resources,
+		NotResources:  s.NotResources,
+		Principals:    toPolicyDocPrincipals(s.Principals),
+		NotPrincipals: toPolicyDocPrincipals(s.NotPrincipals),
+		Conditions:    toPolicyDocConditions(s.Condition),
+	}
+}
+
+func toPolicyDocPrincipals(models []principalModel) []policydoc.Principal {
+	principals := make([]policydoc.Principal, 0, len(models))
+	for _, m := range models {
+		principals = append(principals, policydoc.Principal{Type: m.Type, Identifiers: m.Identifiers})
+	}
+	return principals
+}
+
+func toPolicyDocConditions(models []conditionModel) []policydoc.Condition {
+	conditions := make([]policydoc.Condition, 0, len(models))
+	for _, m := range models {
+		conditions = append(conditions, policydoc.Condition{Test: m.Test, Variable: m.Variable, Values: m.Values})
+	}
+	return conditions
+}
+
+// buildPolicyDocumentJSON assembles and renders a policy document from
+// this data source's statements plus any source/override documents.
+func buildPolicyDocumentJSON(statements []statementModel, sourceDocs []string, overrideDocs []string) (string, error) {
+	docStatements := make([]policydoc.Statement, 0, len(statements))
+	for _, s := range statements {
+		docStatements = append(docStatements, s.toPolicyDoc())
+	}
+
+	sourceStatements, err := parseDocumentList(sourceDocs)
+	if err != nil {
+		return "", err
+	}
+	overrideStatements, err := parseDocumentList(overrideDocs)
+	if err != nil {
+		return "", err
+	}
+
+	merged := policydoc.Build(docStatements, sourceStatements, overrideStatements)
+	return policydoc.Render(policydoc.Document{Statement: merged})
+}
+
+func parseDocumentList(docs []string) ([][]policydoc.Statement, error) {
+	parsed := make([][]policydoc.Statement, 0, len(docs))
+	for _, doc := range docs {
+		stmts, err := policydoc.ParseStatements(doc)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, stmts)
+	}
+	return parsed, nil
+}
+
+func (d *PolicyDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data struct {
+		PolicyType              types.String     `tfsdk:"policy_type"`
+		ResourceType            types.String     `tfsdk:"resource_type"`
+		SourcePolicyDocuments   []string         `tfsdk:"source_policy_documents"`
+		OverridePolicyDocuments []string         `tfsdk:"override_policy_documents"`
+		Statement               []statementModel `tfsdk:"statement"`
+		JSON                    types.String     `tfsdk:"json"`
+		Findings                []Finding        `tfsdk:"findings"`
+		LocalErrors             []string         `tfsdk:"local_errors"`
+		SkipRemote              types.Bool       `tfsdk:"skip_remote"`
+	}
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	renderedJSON, err := buildPolicyDocumentJSON(data.Statement, data.SourcePolicyDocuments, data.OverridePolicyDocuments)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building policy document", err.Error())
+		return
+	}
+	data.JSON = types.StringValue(renderedJSON)
+
+	result, usageErr, awsErr := runValidatePolicy(ctx, d.data, renderedJSON, data.PolicyType.ValueString(), data.ResourceType.ValueString(), data.SkipRemote.ValueBool())
+	if usageErr != nil {
+		resp.Diagnostics.AddError("Invalid argument", usageErr.Error())
+		return
+	}
+	if awsErr != nil {
+		resp.Diagnostics.AddError("ValidatePolicy error", awsErr.Error())
+		return
+	}
+
+	data.Findings = result.Findings
+	data.LocalErrors = result.LocalErrors
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -2,20 +2,19 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
-	awstypes "github.com/aws/aws-sdk-go-v2/service/accessanalyzer/types"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ datasource.DataSource = &ValidatePolicyDataSource{}
+var _ datasource.DataSourceWithConfigure = &ValidatePolicyDataSource{}
 
 // ValidatePolicyDataSource implements datasource.DataSource for AWS IAM policy validation.
-type ValidatePolicyDataSource struct{}
+type ValidatePolicyDataSource struct {
+	data ProviderData
+}
 
 func NewValidatePolicyDataSource() datasource.DataSource {
 	return &ValidatePolicyDataSource{}
@@ -25,6 +24,14 @@ func (d *ValidatePolicyDataSource) Metadata(ctx context.Context, req datasource.
 	resp.TypeName = "aws-iam-validator"
 }
 
+func (d *ValidatePolicyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, ok := providerDataFromConfigureRequest(req, resp)
+	if !ok {
+		return
+	}
+	d.data = data
+}
+
 func (d *ValidatePolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Validates an AWS IAM policy JSON string using the AWS Access Analyzer ValidatePolicy API.",
@@ -33,19 +40,36 @@ func (d *ValidatePolicyDataSource) Schema(ctx context.Context, req datasource.Sc
 				Description: "IAM policy JSON string to validate.",
 				Required:    true,
 			},
-			"findings": schema.ListAttribute{
-				Description: "List of findings from the AWS ValidatePolicy API.",
+			"policy_type": schema.StringAttribute{
+				Description: "The type of policy to validate. One of IDENTITY_POLICY, RESOURCE_POLICY, or SERVICE_CONTROL_POLICY. Defaults to IDENTITY_POLICY.",
+				Optional:    true,
+			},
+			"resource_type": schema.StringAttribute{
+				Description: "The resource type the policy applies to, e.g. AWS::S3::Bucket, AWS::KMS::Key, or AWS::IAM::AssumeRolePolicyDocument. Only used when policy_type is RESOURCE_POLICY.",
+				Optional:    true,
+			},
+			"findings": findingsSchemaAttribute("List of findings from the AWS ValidatePolicy API."),
+			"local_errors": schema.ListAttribute{
+				Description: "Structural problems found by local, client-side validation before calling AWS, such as a malformed JSON document or a stray UTF-8 BOM. If this is non-empty and the document could not be parsed at all, ValidatePolicy is not called and findings will be empty.",
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"skip_remote": schema.BoolAttribute{
+				Description: "Skip calling ValidatePolicy and return only local findings. Also true whenever the provider is configured with offline = true.",
+				Optional:    true,
+			},
 		},
 	}
 }
 
 func (d *ValidatePolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data struct {
-		PolicyJSON types.String `tfsdk:"policy_json"`
-		Findings   []string     `tfsdk:"findings"`
+		PolicyJSON   types.String `tfsdk:"policy_json"`
+		PolicyType   types.String `tfsdk:"policy_type"`
+		ResourceType types.String `tfsdk:"resource_type"`
+		Findings     []Finding    `tfsdk:"findings"`
+		LocalErrors  []string     `tfsdk:"local_errors"`
+		SkipRemote   types.Bool   `tfsdk:"skip_remote"`
 	}
 
 	diags := req.Config.Get(ctx, &data)
@@ -54,32 +78,18 @@ func (d *ValidatePolicyDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	cfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("AWS config error", err.Error())
+	result, usageErr, awsErr := runValidatePolicy(ctx, d.data, data.PolicyJSON.ValueString(), data.PolicyType.ValueString(), data.ResourceType.ValueString(), data.SkipRemote.ValueBool())
+	if usageErr != nil {
+		resp.Diagnostics.AddError("Invalid argument", usageErr.Error())
 		return
 	}
-
-	client := accessanalyzer.NewFromConfig(cfg)
-	policyDoc := data.PolicyJSON.ValueString()
-	input := &accessanalyzer.ValidatePolicyInput{
-		PolicyDocument: &policyDoc,
-		PolicyType:     awstypes.PolicyType("IDENTITY_POLICY"),
-	}
-
-	result, err := client.ValidatePolicy(ctx, input)
-	if err != nil {
-		resp.Diagnostics.AddError("ValidatePolicy error", err.Error())
+	if awsErr != nil {
+		resp.Diagnostics.AddError("ValidatePolicy error", awsErr.Error())
 		return
 	}
 
-	findings := []string{}
-	for _, finding := range result.Findings {
-		msg, _ := json.Marshal(finding)
-		findings = append(findings, string(msg))
-	}
-
-	data.Findings = findings
+	data.Findings = result.Findings
+	data.LocalErrors = result.LocalErrors
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ValidatePoliciesDataSource{}
+var _ datasource.DataSourceWithConfigure = &ValidatePoliciesDataSource{}
+
+// ValidatePoliciesDataSource implements datasource.DataSource. It
+// validates a batch of named policies concurrently and returns a map of
+// per-policy results, optionally filtered to a minimum severity and
+// failing the read entirely if any policy has a finding type in
+// fail_on.
+type ValidatePoliciesDataSource struct {
+	data ProviderData
+}
+
+func NewValidatePoliciesDataSource() datasource.DataSource {
+	return &ValidatePoliciesDataSource{}
+}
+
+func (d *ValidatePoliciesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "aws-iam-validator_validate_policies"
+}
+
+func (d *ValidatePoliciesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, ok := providerDataFromConfigureRequest(req, resp)
+	if !ok {
+		return
+	}
+	d.data = data
+}
+
+func (d *ValidatePoliciesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Validates a batch of named IAM policies concurrently using the AWS Access Analyzer ValidatePolicy API.",
+		Attributes: map[string]schema.Attribute{
+			"min_severity": schema.StringAttribute{
+				Description: "Drop findings ranked below this severity from the results. One of SUGGESTION, WARNING, SECURITY_WARNING, or ERROR.",
+				Optional:    true,
+			},
+			"fail_on": schema.ListAttribute{
+				Description: "Finding types that cause this data source to error instead of merely returning results, e.g. [\"ERROR\", \"SECURITY_WARNING\"].",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"skip_remote": schema.BoolAttribute{
+				Description: "Skip calling ValidatePolicy for every policy in this batch and return only local findings. Also true whenever the provider is configured with offline = true.",
+				Optional:    true,
+			},
+			"results": schema.MapNestedAttribute{
+				Description: "Per-policy validation results, keyed by the policy's name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"findings":     findingsSchemaAttribute("List of findings from the AWS ValidatePolicy API, filtered to min_severity."),
+						"local_errors": schema.ListAttribute{Computed: true, ElementType: types.StringType},
+						"error":        schema.StringAttribute{Computed: true, Description: "Set if this policy could not be validated at all, e.g. a bad policy_type or an AWS API error."},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"policy": schema.ListNestedBlock{
+				Description: "A named policy to validate.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Key this policy's results are returned under.",
+							Required:    true,
+						},
+						"policy_json": schema.StringAttribute{
+							Description: "IAM policy JSON string to validate.",
+							Required:    true,
+						},
+						"policy_type": schema.StringAttribute{
+							Description: "One of IDENTITY_POLICY, RESOURCE_POLICY, or SERVICE_CONTROL_POLICY. Defaults to IDENTITY_POLICY.",
+							Optional:    true,
+						},
+						"resource_type": schema.StringAttribute{
+							Description: "Only used when policy_type is RESOURCE_POLICY.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ValidatePoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data struct {
+		Policies    []PolicyInput           `tfsdk:"policy"`
+		MinSeverity types.String            `tfsdk:"min_severity"`
+		FailOn      []string                `tfsdk:"fail_on"`
+		SkipRemote  types.Bool              `tfsdk:"skip_remote"`
+		Results     map[string]PolicyResult `tfsdk:"results"`
+	}
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results := batchValidatePolicies(ctx, d.data, data.Policies, data.MinSeverity.ValueString(), data.SkipRemote.ValueBool())
+	for name, result := range results {
+		if matchesFailOn(result.Findings, data.FailOn) {
+			resp.Diagnostics.AddError(
+				"Policy failed validation",
+				fmt.Sprintf("policy %q has a finding type in fail_on", name),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Results = results
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/accessanalyzer/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var checkAccessNotGrantedReturnAttrTypes = map[string]attr.Type{
+	"result":  types.StringType,
+	"reasons": types.ListType{ElemType: types.StringType},
+}
+
+var _ function.Function = &CheckAccessNotGrantedFunction{}
+
+// CheckAccessNotGrantedFunction calls the AWS Access Analyzer
+// CheckAccessNotGranted API to confirm a policy never grants the given
+// actions on the given resources, so plans can gate on "this policy never
+// grants s3:DeleteBucket on *".
+type CheckAccessNotGrantedFunction struct{}
+
+func NewCheckAccessNotGrantedFunction() function.Function {
+	return &CheckAccessNotGrantedFunction{}
+}
+
+func (f *CheckAccessNotGrantedFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "check_access_not_granted"
+}
+
+func (f *CheckAccessNotGrantedFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check that a policy never grants a set of actions on a set of resources.",
+		Description: "Calls the AWS Access Analyzer CheckAccessNotGranted API to confirm policy_json never grants actions on resources. Returns result = \"PASS\" or \"FAIL\" and, on FAIL, a list of human-readable reasons.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "policy_json",
+				Description: "IAM policy JSON string to check.",
+			},
+			function.ListParameter{
+				Name:        "actions",
+				Description: "Actions that must never be granted, e.g. [\"s3:DeleteBucket\"].",
+				ElementType: types.StringType,
+			},
+			function.ListParameter{
+				Name:        "resources",
+				Description: "Resources the actions must never be granted on, e.g. [\"*\"].",
+				ElementType: types.StringType,
+			},
+			function.StringParameter{
+				Name:               "policy_type",
+				Description:        "One of IDENTITY_POLICY or RESOURCE_POLICY. Defaults to IDENTITY_POLICY.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.BoolParameter{
+				Name:               "skip_remote",
+				Description:        "Has no local equivalent, so this function errors instead of running if this is true or the provider is configured with offline = true.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: checkAccessNotGrantedReturnAttrTypes,
+		},
+	}
+}
+
+func (f *CheckAccessNotGrantedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	tflog.Info(ctx, "CheckAccessNotGrantedFunction.Run")
+
+	var policyJSON string
+	var actionsList types.List
+	var resourcesList types.List
+	var policyTypeArg types.String
+	var skipRemoteArg types.Bool
+	resp.Error = req.Arguments.Get(ctx, &policyJSON, &actionsList, &resourcesList, &policyTypeArg, &skipRemoteArg)
+	if resp.Error != nil {
+		tflog.Error(ctx, fmt.Sprintf("CheckAccessNotGrantedFunction error: %s", resp.Error.Error()))
+		return
+	}
+
+	var actions []string
+	resp.Error = function.FuncErrorFromDiags(ctx, actionsList.ElementsAs(ctx, &actions, false))
+	if resp.Error != nil {
+		return
+	}
+	var resources []string
+	resp.Error = function.FuncErrorFromDiags(ctx, resourcesList.ElementsAs(ctx, &resources, false))
+	if resp.Error != nil {
+		return
+	}
+
+	policyType := policyTypeArg.ValueString()
+	if policyType == "" {
+		policyType = "IDENTITY_POLICY"
+	}
+	if !checkNoNewAccessPolicyTypes[policyType] {
+		resp.Error = function.NewArgumentFuncError(3, fmt.Sprintf("policy_type must be one of IDENTITY_POLICY or RESOURCE_POLICY, got %q", policyType))
+		return
+	}
+
+	if skipRemoteArg.ValueBool() {
+		resp.Error = function.NewFuncError("check_access_not_granted has no local equivalent and cannot run with skip_remote = true or the provider's offline = true")
+		return
+	}
+
+	providerData, err := providerDataForFunction(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	if providerData.Offline {
+		resp.Error = function.NewFuncError("check_access_not_granted has no local equivalent and cannot run with skip_remote = true or the provider's offline = true")
+		return
+	}
+
+	client := newAccessAnalyzerClient(providerData)
+	out, err := client.CheckAccessNotGranted(ctx, &accessanalyzer.CheckAccessNotGrantedInput{
+		PolicyDocument: &policyJSON,
+		Access: []awstypes.Access{
+			{Actions: actions, Resources: resources},
+		},
+		PolicyType: awstypes.AccessCheckPolicyType(policyType),
+	})
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("CheckAccessNotGranted error: %s", err.Error()))
+		return
+	}
+
+	reasons := make([]attr.Value, 0, len(out.Reasons))
+	for _, reason := range out.Reasons {
+		if reason.Description != nil {
+			reasons = append(reasons, types.StringValue(*reason.Description))
+		}
+	}
+
+	outputObj, diags := types.ObjectValue(checkAccessNotGrantedReturnAttrTypes, map[string]attr.Value{
+		"result":  types.StringValue(string(out.Result)),
+		"reasons": types.ListValueMust(types.StringType, reasons),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, &outputObj)
+}
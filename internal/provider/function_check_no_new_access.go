@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/accessanalyzer/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// checkNoNewAccessPolicyTypes are the policy types CheckNoNewAccess
+// accepts. Unlike ValidatePolicy, it has no SERVICE_CONTROL_POLICY.
+var checkNoNewAccessPolicyTypes = map[string]bool{
+	"IDENTITY_POLICY": true,
+	"RESOURCE_POLICY": true,
+}
+
+var checkNoNewAccessReturnAttrTypes = map[string]attr.Type{
+	"result":  types.StringType,
+	"reasons": types.ListType{ElemType: types.StringType},
+}
+
+var _ function.Function = &CheckNoNewAccessFunction{}
+
+// CheckNoNewAccessFunction calls the AWS Access Analyzer CheckNoNewAccess
+// API to confirm that an updated policy doesn't grant any access the
+// existing policy didn't already grant, so plans can gate on "this change
+// doesn't widen permissions".
+type CheckNoNewAccessFunction struct{}
+
+func NewCheckNoNewAccessFunction() function.Function {
+	return &CheckNoNewAccessFunction{}
+}
+
+func (f *CheckNoNewAccessFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "check_no_new_access"
+}
+
+func (f *CheckNoNewAccessFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check that a policy change doesn't grant any new access.",
+		Description: "Calls the AWS Access Analyzer CheckNoNewAccess API to confirm updated_policy_json grants no access beyond what existing_policy_json already grants. Returns result = \"PASS\" or \"FAIL\" and, on FAIL, a list of human-readable reasons.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "existing_policy_json",
+				Description: "The existing IAM policy JSON to compare against.",
+			},
+			function.StringParameter{
+				Name:        "updated_policy_json",
+				Description: "The updated IAM policy JSON to check for new access.",
+			},
+			function.StringParameter{
+				Name:               "policy_type",
+				Description:        "One of IDENTITY_POLICY or RESOURCE_POLICY. Defaults to IDENTITY_POLICY.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.BoolParameter{
+				Name:               "skip_remote",
+				Description:        "Has no local equivalent, so this function errors instead of running if this is true or the provider is configured with offline = true.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: checkNoNewAccessReturnAttrTypes,
+		},
+	}
+}
+
+func (f *CheckNoNewAccessFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	tflog.Info(ctx, "CheckNoNewAccessFunction.Run")
+
+	var existingPolicyJSON string
+	var updatedPolicyJSON string
+	var policyTypeArg types.String
+	var skipRemoteArg types.Bool
+	resp.Error = req.Arguments.Get(ctx, &existingPolicyJSON, &updatedPolicyJSON, &policyTypeArg, &skipRemoteArg)
+	if resp.Error != nil {
+		tflog.Error(ctx, fmt.Sprintf("CheckNoNewAccessFunction error: %s", resp.Error.Error()))
+		return
+	}
+
+	policyType := policyTypeArg.ValueString()
+	if policyType == "" {
+		policyType = "IDENTITY_POLICY"
+	}
+	if !checkNoNewAccessPolicyTypes[policyType] {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("policy_type must be one of IDENTITY_POLICY or RESOURCE_POLICY, got %q", policyType))
+		return
+	}
+
+	if skipRemoteArg.ValueBool() {
+		resp.Error = function.NewFuncError("check_no_new_access has no local equivalent and cannot run with skip_remote = true or the provider's offline = true")
+		return
+	}
+
+	providerData, err := providerDataForFunction(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	if providerData.Offline {
+		resp.Error = function.NewFuncError("check_no_new_access has no local equivalent and cannot run with skip_remote = true or the provider's offline = true")
+		return
+	}
+
+	client := newAccessAnalyzerClient(providerData)
+	out, err := client.CheckNoNewAccess(ctx, &accessanalyzer.CheckNoNewAccessInput{
+		ExistingPolicyDocument: &existingPolicyJSON,
+		NewPolicyDocument:      &updatedPolicyJSON,
+		PolicyType:             awstypes.AccessCheckPolicyType(policyType),
+	})
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("CheckNoNewAccess error: %s", err.Error()))
+		return
+	}
+
+	reasons := make([]attr.Value, 0, len(out.Reasons))
+	for _, reason := range out.Reasons {
+		if reason.Description != nil {
+			reasons = append(reasons, types.StringValue(*reason.Description))
+		}
+	}
+
+	outputObj, diags := types.ObjectValue(checkNoNewAccessReturnAttrTypes, map[string]attr.Value{
+		"result":  types.StringValue(string(out.Result)),
+		"reasons": types.ListValueMust(types.StringType, reasons),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, &outputObj)
+}
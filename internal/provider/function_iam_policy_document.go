@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// principalObjectType, conditionObjectType, and statementObjectType
+// describe the object shapes accepted by the policy_document function's
+// "statements" parameter; they mirror the statement/principals/condition
+// blocks of the policy_document data source.
+var principalObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":        types.StringType,
+	"identifiers": types.ListType{ElemType: types.StringType},
+}}
+
+var conditionObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"test":     types.StringType,
+	"variable": types.StringType,
+	"values":   types.ListType{ElemType: types.StringType},
+}}
+
+var statementObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"sid":            types.StringType,
+	"effect":         types.StringType,
+	"actions":        types.ListType{ElemType: types.StringType},
+	"not_actions":    types.ListType{ElemType: types.StringType},
+	"resources":      types.ListType{ElemType: types.StringType},
+	"not_resources":  types.ListType{ElemType: types.StringType},
+	"principals":     types.ListType{ElemType: principalObjectType},
+	"not_principals": types.ListType{ElemType: principalObjectType},
+	"condition":      types.ListType{ElemType: conditionObjectType},
+}}
+
+var policyDocumentReturnAttrTypes = map[string]attr.Type{
+	"json":         types.StringType,
+	"errors":       types.ListType{ElemType: findingObjectType},
+	"local_errors": types.ListType{ElemType: types.StringType},
+}
+
+var _ function.Function = &PolicyDocumentFunction{}
+
+// PolicyDocumentFunction builds an IAM policy document from structured
+// statements and validates it through the same path as
+// ValidatePolicyFunction.
+type PolicyDocumentFunction struct{}
+
+func NewPolicyDocumentFunction() function.Function {
+	return &PolicyDocumentFunction{}
+}
+
+func (f *PolicyDocumentFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "policy_document"
+}
+
+func (f *PolicyDocumentFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build and validate an IAM policy document from structured statements.",
+		Description: "Assembles an IAM policy JSON document from a list of statement objects (modeled on aws_iam_policy_document), merges it with any source/override policy documents, and validates the result with ValidatePolicy.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "statements",
+				Description: "List of statement objects: sid, effect, actions, not_actions, resources, not_resources, principals, not_principals, condition.",
+				ElementType: statementObjectType,
+			},
+			function.ListParameter{
+				Name:               "source_policy_documents",
+				Description:        "List of IAM policy JSON documents to merge in. Statements are matched by Sid; of two source documents sharing a Sid, the later one in the list wins.",
+				ElementType:        types.StringType,
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.ListParameter{
+				Name:               "override_policy_documents",
+				Description:        "List of IAM policy JSON documents whose statements override everything else by Sid. Of two override documents sharing a Sid, the earlier one in the list wins.",
+				ElementType:        types.StringType,
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.StringParameter{
+				Name:               "policy_type",
+				Description:        "The type of policy to validate. One of IDENTITY_POLICY, RESOURCE_POLICY, or SERVICE_CONTROL_POLICY. Defaults to IDENTITY_POLICY.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.StringParameter{
+				Name:               "resource_type",
+				Description:        "The resource type the policy applies to. Only used when policy_type is RESOURCE_POLICY.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.BoolParameter{
+				Name:               "skip_remote",
+				Description:        "Skip calling ValidatePolicy and return only local findings. Also true whenever the provider is configured with offline = true.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: policyDocumentReturnAttrTypes,
+		},
+	}
+}
+
+func (f *PolicyDocumentFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	tflog.Info(ctx, "PolicyDocumentFunction.Run")
+
+	var statementsList types.List
+	var sourceDocsList types.List
+	var overrideDocsList types.List
+	var policyTypeArg types.String
+	var resourceTypeArg types.String
+	var skipRemoteArg types.Bool
+	resp.Error = req.Arguments.Get(ctx, &statementsList, &sourceDocsList, &overrideDocsList, &policyTypeArg, &resourceTypeArg, &skipRemoteArg)
+	if resp.Error != nil {
+		tflog.Error(ctx, fmt.Sprintf("PolicyDocumentFunction error: %s", resp.Error.Error()))
+		return
+	}
+
+	var statements []statementModel
+	resp.Error = function.FuncErrorFromDiags(ctx, statementsList.ElementsAs(ctx, &statements, false))
+	if resp.Error != nil {
+		return
+	}
+
+	var sourceDocs []string
+	if !sourceDocsList.IsNull() {
+		resp.Error = function.FuncErrorFromDiags(ctx, sourceDocsList.ElementsAs(ctx, &sourceDocs, false))
+		if resp.Error != nil {
+			return
+		}
+	}
+
+	var overrideDocs []string
+	if !overrideDocsList.IsNull() {
+		resp.Error = function.FuncErrorFromDiags(ctx, overrideDocsList.ElementsAs(ctx, &overrideDocs, false))
+		if resp.Error != nil {
+			return
+		}
+	}
+
+	renderedJSON, err := buildPolicyDocumentJSON(statements, sourceDocs, overrideDocs)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("error building policy document: %s", err.Error()))
+		return
+	}
+
+	providerData, err := providerDataForFunction(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	result, usageErr, awsErr := runValidatePolicy(ctx, providerData, renderedJSON, policyTypeArg.ValueString(), resourceTypeArg.ValueString(), skipRemoteArg.ValueBool())
+	if usageErr != nil {
+		resp.Error = function.NewArgumentFuncError(3, usageErr.Error())
+		return
+	}
+	if awsErr != nil {
+		resp.Error = function.NewFuncError(awsErr.Error())
+		return
+	}
+
+	errors, diags := types.ListValueFrom(ctx, findingObjectType, result.Findings)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	localErrors := make([]attr.Value, 0, len(result.LocalErrors))
+	for _, msg := range result.LocalErrors {
+		localErrors = append(localErrors, types.StringValue(msg))
+	}
+
+	outputObj, diags := types.ObjectValue(policyDocumentReturnAttrTypes, map[string]attr.Value{
+		"json":         types.StringValue(renderedJSON),
+		"errors":       errors,
+		"local_errors": types.ListValueMust(types.StringType, localErrors),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, &outputObj)
+}
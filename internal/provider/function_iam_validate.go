@@ -5,12 +5,8 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
-	awstypes "github.com/aws/aws-sdk-go-v2/service/accessanalyzer/types"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -18,7 +14,8 @@ import (
 )
 
 var validatePolicyReturnAttrTypes = map[string]attr.Type{
-	"errors": types.ListType{ElemType: types.StringType},
+	"errors":       types.ListType{ElemType: findingObjectType},
+	"local_errors": types.ListType{ElemType: types.StringType},
 }
 
 var _ function.Function = &ValidatePolicyFunction{}
@@ -44,6 +41,24 @@ func (f *ValidatePolicyFunction) Definition(ctx context.Context, req function.De
 				Name:        "policy_json",
 				Description: "IAM policy JSON string to validate.",
 			},
+			function.StringParameter{
+				Name:               "policy_type",
+				Description:        "The type of policy to validate. One of IDENTITY_POLICY, RESOURCE_POLICY, or SERVICE_CONTROL_POLICY. Defaults to IDENTITY_POLICY.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.StringParameter{
+				Name:               "resource_type",
+				Description:        "The resource type the policy applies to, e.g. AWS::S3::Bucket, AWS::KMS::Key, or AWS::IAM::AssumeRolePolicyDocument. Only used when policy_type is RESOURCE_POLICY.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.BoolParameter{
+				Name:               "skip_remote",
+				Description:        "Skip calling ValidatePolicy and return only local findings. Also true whenever the provider is configured with offline = true.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
 		},
 		Return: function.ObjectReturn{
 			AttributeTypes: validatePolicyReturnAttrTypes,
@@ -52,50 +67,50 @@ func (f *ValidatePolicyFunction) Definition(ctx context.Context, req function.De
 }
 
 func (f *ValidatePolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
-
 	tflog.Info(ctx, "ValidatePolicyFunction.Run")
-	// Get arguments as attr.Value
+
 	var policyJSONVal string
-	resp.Error = req.Arguments.GetArgument(ctx, 0, &policyJSONVal)
+	var policyTypeArg types.String
+	var resourceTypeArg types.String
+	var skipRemoteArg types.Bool
+	resp.Error = req.Arguments.Get(ctx, &policyJSONVal, &policyTypeArg, &resourceTypeArg, &skipRemoteArg)
 	if resp.Error != nil {
 		tflog.Error(ctx, fmt.Sprintf("ValidatePolicyFunction error: %s\n\n", resp.Error.Error()))
 		return
 	}
 
-	var policyType string = "IDENTITY_POLICY"
-	tflog.Info(ctx, "ValidatePolicyFunction.LoadingConfig")
-
-	cfg, err := config.LoadDefaultConfig(ctx)
+	providerData, err := providerDataForFunction(ctx)
 	if err != nil {
-		tflog.Error(ctx, fmt.Sprintf("failed to load AWS config: %s", err.Error()))
-		resp.Error = function.NewFuncError(fmt.Sprintf("AWS config error: %s", err.Error()))
+		resp.Error = function.NewFuncError(err.Error())
 		return
 	}
 
-	client := accessanalyzer.NewFromConfig(cfg)
-	input := &accessanalyzer.ValidatePolicyInput{
-		PolicyDocument: &policyJSONVal,
-		PolicyType:     awstypes.PolicyType(policyType),
+	result, usageErr, awsErr := runValidatePolicy(ctx, providerData, policyJSONVal, policyTypeArg.ValueString(), resourceTypeArg.ValueString(), skipRemoteArg.ValueBool())
+	if usageErr != nil {
+		resp.Error = function.NewArgumentFuncError(1, usageErr.Error())
+		return
 	}
-
-	result, err := client.ValidatePolicy(ctx, input)
-	if err != nil {
-		tflog.Error(ctx, fmt.Sprintf("failed to validate policy: %s", err.Error()))
-		resp.Error = function.NewFuncError(fmt.Sprintf("ValidatePolicy error: %s", err.Error()))
+	if awsErr != nil {
+		tflog.Error(ctx, fmt.Sprintf("ValidatePolicyFunction error: %s", awsErr.Error()))
+		resp.Error = function.NewFuncError(awsErr.Error())
 		return
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("ValidatePolicyFunction found %d findings", len(result.Findings)))
-	tflog.Info(ctx, fmt.Sprintf("Findings: %+v", result.Findings))
 
-	errors := []attr.Value{}
-	for _, finding := range result.Findings {
-		msg, _ := json.Marshal(finding)
-		errors = append(errors, types.StringValue(string(msg)))
+	errors, diags := types.ListValueFrom(ctx, findingObjectType, result.Findings)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	localErrors := make([]attr.Value, 0, len(result.LocalErrors))
+	for _, msg := range result.LocalErrors {
+		localErrors = append(localErrors, types.StringValue(msg))
 	}
 
 	outputObj, diags := types.ObjectValue(validatePolicyReturnAttrTypes, map[string]attr.Value{
-		"errors": types.ListValueMust(types.StringType, errors),
+		"errors":       errors,
+		"local_errors": types.ListValueMust(types.StringType, localErrors),
 	})
 	resp.Error = function.FuncErrorFromDiags(ctx, diags)
 	if resp.Error != nil {
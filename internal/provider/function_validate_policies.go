@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// policyInputObjectType describes the objects accepted by the
+// validate_policies function's "policies" parameter.
+var policyInputObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":          types.StringType,
+	"policy_json":   types.StringType,
+	"policy_type":   types.StringType,
+	"resource_type": types.StringType,
+}}
+
+var policyResultObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"findings":     types.ListType{ElemType: findingObjectType},
+	"local_errors": types.ListType{ElemType: types.StringType},
+	"error":        types.StringType,
+}}
+
+var _ function.Function = &ValidatePoliciesFunction{}
+
+// ValidatePoliciesFunction validates a batch of named policies
+// concurrently and returns a map of per-policy results. As with the data
+// source, a fail_on match aborts the whole call (here with a FuncError
+// naming every matching policy) rather than merely being reported
+// per-policy.
+type ValidatePoliciesFunction struct{}
+
+func NewValidatePoliciesFunction() function.Function {
+	return &ValidatePoliciesFunction{}
+}
+
+func (f *ValidatePoliciesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_policies"
+}
+
+func (f *ValidatePoliciesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Validate a batch of named IAM policies concurrently.",
+		Description: "Validates each of a list of named policies against ValidatePolicy, returning a map of results keyed by name. min_severity filters returned findings; fail_on errors the call entirely if any policy has a matching finding type.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "policies",
+				Description: "List of objects: name, policy_json, policy_type, resource_type.",
+				ElementType: policyInputObjectType,
+			},
+			function.StringParameter{
+				Name:               "min_severity",
+				Description:        "Drop findings ranked below this severity. One of SUGGESTION, WARNING, SECURITY_WARNING, or ERROR.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.ListParameter{
+				Name:               "fail_on",
+				Description:        "Finding types that cause this function to error instead of returning results.",
+				ElementType:        types.StringType,
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			function.BoolParameter{
+				Name:               "skip_remote",
+				Description:        "Skip calling ValidatePolicy for every policy in this batch and return only local findings. Also true whenever the provider is configured with offline = true.",
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: policyResultObjectType,
+		},
+	}
+}
+
+func (f *ValidatePoliciesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	tflog.Info(ctx, "ValidatePoliciesFunction.Run")
+
+	var policiesList types.List
+	var minSeverityArg types.String
+	var failOnList types.List
+	var skipRemoteArg types.Bool
+	resp.Error = req.Arguments.Get(ctx, &policiesList, &minSeverityArg, &failOnList, &skipRemoteArg)
+	if resp.Error != nil {
+		tflog.Error(ctx, fmt.Sprintf("ValidatePoliciesFunction error: %s", resp.Error.Error()))
+		return
+	}
+
+	var policies []PolicyInput
+	resp.Error = function.FuncErrorFromDiags(ctx, policiesList.ElementsAs(ctx, &policies, false))
+	if resp.Error != nil {
+		return
+	}
+
+	var failOn []string
+	if !failOnList.IsNull() {
+		resp.Error = function.FuncErrorFromDiags(ctx, failOnList.ElementsAs(ctx, &failOn, false))
+		if resp.Error != nil {
+			return
+		}
+	}
+
+	providerData, err := providerDataForFunction(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	results := batchValidatePolicies(ctx, providerData, policies, minSeverityArg.ValueString(), skipRemoteArg.ValueBool())
+
+	failedNames := []string{}
+	for name, result := range results {
+		if matchesFailOn(result.Findings, failOn) {
+			failedNames = append(failedNames, name)
+		}
+	}
+	if len(failedNames) > 0 {
+		sort.Strings(failedNames)
+		resp.Error = function.NewFuncError(fmt.Sprintf("policies with a disallowed finding type: %s", strings.Join(failedNames, ", ")))
+		return
+	}
+
+	resultsValue, diags := types.MapValueFrom(ctx, policyResultObjectType, results)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, &resultsValue)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ provider.Provider = &AWSIAMValidatorProvider{}
+var _ provider.ProviderWithFunctions = &AWSIAMValidatorProvider{}
+
+// AWSIAMValidatorProvider implements provider.Provider. It resolves an
+// aws.Config once in Configure, honoring region/profile/assume-role/
+// endpoint overrides, and threads that configuration into every data
+// source so they don't each call config.LoadDefaultConfig per Read.
+type AWSIAMValidatorProvider struct {
+	version string
+}
+
+// New returns a provider.Provider factory, suitable for
+// providerserver.NewProtocol6 and friends.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &AWSIAMValidatorProvider{version: version}
+	}
+}
+
+func (p *AWSIAMValidatorProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "aws-iam-validator"
+	resp.Version = p.version
+}
+
+// assumeRoleModel and endpointsModel back the provider schema's
+// assume_role and endpoints blocks.
+type assumeRoleModel struct {
+	RoleARN     string `tfsdk:"role_arn"`
+	SessionName string `tfsdk:"session_name"`
+	ExternalID  string `tfsdk:"external_id"`
+	Duration    string `tfsdk:"duration"`
+}
+
+type endpointsModel struct {
+	AccessAnalyzer string `tfsdk:"accessanalyzer"`
+}
+
+type providerConfigModel struct {
+	Region            types.String     `tfsdk:"region"`
+	Profile           types.String     `tfsdk:"profile"`
+	SharedConfigFiles []string         `tfsdk:"shared_config_files"`
+	MaxRetries        types.Int64      `tfsdk:"max_retries"`
+	Offline           types.Bool       `tfsdk:"offline"`
+	AssumeRole        *assumeRoleModel `tfsdk:"assume_role"`
+	Endpoints         *endpointsModel  `tfsdk:"endpoints"`
+}
+
+func (p *AWSIAMValidatorProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Validates AWS IAM policies with AWS Access Analyzer.",
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Description: "AWS region to call Access Analyzer in. Defaults to the standard AWS SDK resolution order.",
+				Optional:    true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Named AWS shared config/credentials profile to use.",
+				Optional:    true,
+			},
+			"shared_config_files": schema.ListAttribute{
+				Description: "Additional shared AWS config file paths to load, on top of the default ~/.aws/config.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of retries for AWS API calls.",
+				Optional:    true,
+			},
+			"offline": schema.BoolAttribute{
+				Description: "Never call Access Analyzer: every data source and function returns only local, client-side findings. Neither an AWS config nor credentials are resolved. Equivalent to passing skip_remote = true everywhere.",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"assume_role": schema.SingleNestedBlock{
+				Description: "Assume an IAM role before calling Access Analyzer, for cross-account validation.",
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						Description: "ARN of the role to assume.",
+						Optional:    true,
+					},
+					"session_name": schema.StringAttribute{
+						Description: "Session name to use for the assumed role.",
+						Optional:    true,
+					},
+					"external_id": schema.StringAttribute{
+						Description: "External ID to pass when assuming the role.",
+						Optional:    true,
+					},
+					"duration": schema.StringAttribute{
+						Description: "Duration of the assumed role session, as a Go duration string, e.g. \"1h\".",
+						Optional:    true,
+					},
+				},
+			},
+			"endpoints": schema.SingleNestedBlock{
+				Description: "Service endpoint overrides, e.g. for LocalStack or a private VPC endpoint.",
+				Attributes: map[string]schema.Attribute{
+					"accessanalyzer": schema.StringAttribute{
+						Description: "Custom Access Analyzer endpoint URL.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *AWSIAMValidatorProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config providerConfigModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Offline.ValueBool() {
+		data := ProviderData{Offline: true}
+		resp.DataSourceData = data
+		setConfiguredProviderData(data)
+		return
+	}
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if !config.Region.IsNull() && config.Region.ValueString() != "" {
+		opts = append(opts, awsconfig.WithRegion(config.Region.ValueString()))
+	}
+	if !config.Profile.IsNull() && config.Profile.ValueString() != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(config.Profile.ValueString()))
+	}
+	if len(config.SharedConfigFiles) > 0 {
+		opts = append(opts, awsconfig.WithSharedConfigFiles(config.SharedConfigFiles))
+	}
+	if !config.MaxRetries.IsNull() {
+		// WithRetryMaxAttempts counts the initial attempt too.
+		opts = append(opts, awsconfig.WithRetryMaxAttempts(int(config.MaxRetries.ValueInt64())+1))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		resp.Diagnostics.AddError("AWS config error", err.Error())
+		return
+	}
+
+	if config.AssumeRole != nil && config.AssumeRole.RoleARN != "" {
+		stsClient := sts.NewFromConfig(cfg)
+		assumeRoleProvider := stscreds.NewAssumeRoleProvider(stsClient, config.AssumeRole.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			if config.AssumeRole.SessionName != "" {
+				o.RoleSessionName = config.AssumeRole.SessionName
+			}
+			if config.AssumeRole.ExternalID != "" {
+				o.ExternalID = aws.String(config.AssumeRole.ExternalID)
+			}
+			if config.AssumeRole.Duration != "" {
+				if d, err := time.ParseDuration(config.AssumeRole.Duration); err == nil {
+					o.Duration = d
+				}
+			}
+		})
+		cfg.Credentials = aws.NewCredentialsCache(assumeRoleProvider)
+	}
+
+	data := ProviderData{Config: cfg}
+	if config.Endpoints != nil {
+		data.AccessAnalyzerEndpoint = config.Endpoints.AccessAnalyzer
+	}
+
+	resp.DataSourceData = data
+
+	// Provider-defined functions don't get a Configure hook the way data
+	// sources do (the plugin framework keeps them stateless), so we stash
+	// the resolved ProviderData here for providerDataForFunction to pick
+	// up instead.
+	setConfiguredProviderData(data)
+}
+
+// providerDataFromConfigureRequest pulls the ProviderData a
+// datasource.ConfigureRequest carries, reporting a diagnostic (and
+// returning ok=false) if it's present but of the wrong type. A nil
+// ProviderData (Configure not yet called, e.g. during certain test
+// setups) is not an error: callers just keep the zero-value ProviderData.
+func providerDataFromConfigureRequest(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) (ProviderData, bool) {
+	if req.ProviderData == nil {
+		return ProviderData{}, true
+	}
+
+	data, ok := req.ProviderData.(ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected provider.ProviderData, got: %T. This is a bug in the provider.", req.ProviderData),
+		)
+		return ProviderData{}, false
+	}
+	return data, true
+}
+
+func (p *AWSIAMValidatorProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewValidatePolicyDataSource,
+		NewPolicyDocumentDataSource,
+		NewValidatePoliciesDataSource,
+	}
+}
+
+func (p *AWSIAMValidatorProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *AWSIAMValidatorProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewValidatePolicyFunction,
+		NewPolicyDocumentFunction,
+		NewValidatePoliciesFunction,
+		NewCheckNoNewAccessFunction,
+		NewCheckAccessNotGrantedFunction,
+	}
+}
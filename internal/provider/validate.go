@@ -0,0 +1,353 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/accessanalyzer/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/JosiahOne/terraform-provider-aws-iam-validator/internal/policycheck"
+)
+
+// validPolicyTypes are the PolicyType values accepted by the AWS Access
+// Analyzer ValidatePolicy API.
+var validPolicyTypes = map[string]bool{
+	"IDENTITY_POLICY":        true,
+	"RESOURCE_POLICY":        true,
+	"SERVICE_CONTROL_POLICY": true,
+}
+
+// severityRank orders ValidatePolicy finding types from least to most
+// severe, so min_severity can compare them (fail_on matches finding
+// types exactly and does not use this ranking). SECURITY_WARNING
+// ranks above the generic WARNING because Access Analyzer only emits it
+// for findings with real security impact.
+var severityRank = map[string]int{
+	"SUGGESTION":       1,
+	"WARNING":          2,
+	"SECURITY_WARNING": 3,
+	"ERROR":            4,
+}
+
+// FindingLocation is a single location a finding points at within the
+// policy document.
+type FindingLocation struct {
+	// Path is the finding's list of path elements, JSON-encoded as-is,
+	// e.g. [{"Value":"Statement"},{"Value":0},{"Value":"Action"}].
+	Path   string `tfsdk:"path"`
+	Line   int64  `tfsdk:"line"`
+	Column int64  `tfsdk:"column"`
+}
+
+// Finding is a structured view of an awstypes.ValidatePolicyFinding, used
+// in place of the raw JSON blob so that `terraform plan` output can
+// surface the finding type, issue code, and locations directly.
+type Finding struct {
+	FindingType   string            `tfsdk:"finding_type"`
+	IssueCode     string            `tfsdk:"issue_code"`
+	Details       string            `tfsdk:"details"`
+	LearnMoreLink string            `tfsdk:"learn_more_link"`
+	Locations     []FindingLocation `tfsdk:"locations"`
+}
+
+var findingLocationObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"path":   types.StringType,
+	"line":   types.Int64Type,
+	"column": types.Int64Type,
+}}
+
+var findingObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"finding_type":    types.StringType,
+	"issue_code":      types.StringType,
+	"details":         types.StringType,
+	"learn_more_link": types.StringType,
+	"locations":       types.ListType{ElemType: findingLocationObjectType},
+}}
+
+// findingsSchemaAttribute returns the Computed ListNestedAttribute shape
+// shared by every data source that surfaces ValidatePolicy findings.
+func findingsSchemaAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: description,
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"finding_type":    schema.StringAttribute{Computed: true},
+				"issue_code":      schema.StringAttribute{Computed: true},
+				"details":         schema.StringAttribute{Computed: true},
+				"learn_more_link": schema.StringAttribute{Computed: true},
+				"locations": schema.ListNestedAttribute{
+					Computed: true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"path":   schema.StringAttribute{Computed: true},
+							"line":   schema.Int64Attribute{Computed: true},
+							"column": schema.Int64Attribute{Computed: true},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// toFinding converts an AWS SDK finding into our structured Finding type.
+func toFinding(f awstypes.ValidatePolicyFinding) Finding {
+	finding := Finding{
+		FindingType: string(f.FindingType),
+	}
+	if f.IssueCode != nil {
+		finding.IssueCode = *f.IssueCode
+	}
+	if f.FindingDetails != nil {
+		finding.Details = *f.FindingDetails
+	}
+	if f.LearnMoreLink != nil {
+		finding.LearnMoreLink = *f.LearnMoreLink
+	}
+
+	for _, loc := range f.Locations {
+		fl := FindingLocation{}
+		if pathBytes, err := json.Marshal(loc.Path); err == nil {
+			fl.Path = string(pathBytes)
+		}
+		if loc.Span != nil && loc.Span.Start != nil {
+			if loc.Span.Start.Line != nil {
+				fl.Line = int64(*loc.Span.Start.Line)
+			}
+			if loc.Span.Start.Column != nil {
+				fl.Column = int64(*loc.Span.Start.Column)
+			}
+		}
+		finding.Locations = append(finding.Locations, fl)
+	}
+
+	return finding
+}
+
+// filterFindingsBySeverity drops findings ranked below minSeverity. An
+// unrecognized or empty minSeverity is treated as "no filtering".
+func filterFindingsBySeverity(findings []Finding, minSeverity string) []Finding {
+	threshold, ok := severityRank[minSeverity]
+	if !ok {
+		return findings
+	}
+
+	filtered := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		if severityRank[f.FindingType] >= threshold {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// matchesFailOn reports whether any finding's type appears in failOn.
+func matchesFailOn(findings []Finding, failOn []string) bool {
+	if len(failOn) == 0 {
+		return false
+	}
+
+	disallowed := make(map[string]bool, len(failOn))
+	for _, t := range failOn {
+		disallowed[t] = true
+	}
+
+	for _, f := range findings {
+		if disallowed[f.FindingType] {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePolicyResult is the outcome of running a policy document
+// through local validation and, if the document isn't fatally malformed,
+// the AWS Access Analyzer ValidatePolicy API. It's shared by the
+// validate_policy function/data source and anything else in this
+// provider that builds a policy document and wants it validated
+// atomically, such as the policy_document and validate_policies
+// function/data sources.
+type validatePolicyResult struct {
+	LocalErrors []string
+	Findings    []Finding
+
+	// fatal is set by localValidator when the document is too malformed
+	// to validate any further. It's internal to the Validator chain and
+	// never surfaced to a caller's state/result.
+	fatal bool
+}
+
+// ProviderData is the aws.Config (and any endpoint overrides) resolved
+// once in the provider's Configure and threaded into every data source
+// and function instead of each Read/Run re-resolving its own default AWS
+// config.
+type ProviderData struct {
+	Config                 aws.Config
+	AccessAnalyzerEndpoint string
+
+	// Offline, when true, makes every data source and function skip
+	// ValidatePolicy (and every other Access Analyzer call) and return
+	// only local findings, regardless of skip_remote.
+	Offline bool
+}
+
+// newAccessAnalyzerClient builds an Access Analyzer client from
+// ProviderData, applying the accessanalyzer endpoint override if one was
+// configured.
+func newAccessAnalyzerClient(data ProviderData) *accessanalyzer.Client {
+	return accessanalyzer.NewFromConfig(data.Config, func(o *accessanalyzer.Options) {
+		if data.AccessAnalyzerEndpoint != "" {
+			o.BaseEndpoint = aws.String(data.AccessAnalyzerEndpoint)
+		}
+	})
+}
+
+// configuredProviderData holds the ProviderData set by
+// AWSIAMValidatorProvider.Configure. Provider-defined functions are
+// stateless in the plugin framework and have no ProviderData wiring of
+// their own, so they fall back to this instead of resolving a fresh
+// default AWS config on every call.
+var (
+	configuredProviderDataMu sync.RWMutex
+	configuredProviderData   *ProviderData
+)
+
+func setConfiguredProviderData(data ProviderData) {
+	configuredProviderDataMu.Lock()
+	defer configuredProviderDataMu.Unlock()
+	configuredProviderData = &data
+}
+
+// providerDataForFunction returns the ProviderData set by the provider's
+// Configure, or resolves a default AWS config if the provider block
+// (and therefore Configure) was never exercised, e.g. in unit tests that
+// call a function directly.
+func providerDataForFunction(ctx context.Context) (ProviderData, error) {
+	configuredProviderDataMu.RLock()
+	data := configuredProviderData
+	configuredProviderDataMu.RUnlock()
+	if data != nil {
+		return *data, nil
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return ProviderData{}, fmt.Errorf("AWS config error: %w", err)
+	}
+	return ProviderData{Config: cfg}, nil
+}
+
+// Validator runs one stage of policy validation and reports its partial
+// result. localValidator and remoteValidator are the two stages;
+// chainValidator composes them.
+type Validator interface {
+	Validate(ctx context.Context, policyJSON, policyType, resourceType string) (validatePolicyResult, error)
+}
+
+// localValidator runs the local, client-side structural checks (JSON
+// syntax, UTF-8 BOM, leading brace) that need no network access. It sets
+// validatePolicyResult.fatal when the document is too malformed to
+// validate any further, which chainValidator uses to stop before running
+// any remote validators that follow it.
+type localValidator struct{}
+
+func (localValidator) Validate(ctx context.Context, policyJSON, policyType, resourceType string) (validatePolicyResult, error) {
+	local := policycheck.CheckPolicyJSON(policyJSON)
+	return validatePolicyResult{LocalErrors: local.Errors, Findings: []Finding{}, fatal: local.Fatal()}, nil
+}
+
+// remoteValidator calls the AWS Access Analyzer ValidatePolicy API.
+type remoteValidator struct {
+	data ProviderData
+}
+
+func (v remoteValidator) Validate(ctx context.Context, policyJSON, policyType, resourceType string) (validatePolicyResult, error) {
+	result := validatePolicyResult{Findings: []Finding{}}
+
+	client := newAccessAnalyzerClient(v.data)
+	input := &accessanalyzer.ValidatePolicyInput{
+		PolicyDocument: &policyJSON,
+		PolicyType:     awstypes.PolicyType(policyType),
+	}
+	if policyType == "RESOURCE_POLICY" && resourceType != "" {
+		input.ValidatePolicyResourceType = awstypes.ValidatePolicyResourceType(resourceType)
+	}
+
+	out, err := client.ValidatePolicy(ctx, input)
+	if err != nil {
+		return result, fmt.Errorf("ValidatePolicy error: %w", err)
+	}
+
+	for _, finding := range out.Findings {
+		result.Findings = append(result.Findings, toFinding(finding))
+	}
+	return result, nil
+}
+
+// chainValidator runs a sequence of Validators in order, merging their
+// LocalErrors and Findings, and stops early (without running the rest of
+// the chain) once a stage reports the document too malformed to validate
+// any further.
+type chainValidator struct {
+	validators []Validator
+}
+
+func (c chainValidator) Validate(ctx context.Context, policyJSON, policyType, resourceType string) (validatePolicyResult, error) {
+	result := validatePolicyResult{Findings: []Finding{}}
+	for _, v := range c.validators {
+		stage, err := v.Validate(ctx, policyJSON, policyType, resourceType)
+		result.LocalErrors = append(result.LocalErrors, stage.LocalErrors...)
+		result.Findings = append(result.Findings, stage.Findings...)
+		if err != nil {
+			return result, err
+		}
+		if stage.fatal {
+			break
+		}
+	}
+	return result, nil
+}
+
+// runValidatePolicy runs policyJSON through a chainValidator and, if
+// those checks don't find a fatal problem, calls ValidatePolicy against
+// it using the given ProviderData's AWS config. The remote stage is
+// skipped entirely - without resolving or touching AWS credentials - if
+// skipRemote is set or data.Offline was configured at the provider level.
+// It returns a usage error (bad policyType/resourceType combination)
+// separately from an AWS-side error, so callers can report them through
+// whichever diagnostics mechanism (datasource.Diagnostics, function.FuncError)
+// they use.
+func runValidatePolicy(ctx context.Context, data ProviderData, policyJSON, policyType, resourceType string, skipRemote bool) (result validatePolicyResult, usageErr error, awsErr error) {
+	if policyType == "" {
+		policyType = "IDENTITY_POLICY"
+	}
+	if !validPolicyTypes[policyType] {
+		return result, fmt.Errorf("policy_type must be one of IDENTITY_POLICY, RESOURCE_POLICY, or SERVICE_CONTROL_POLICY, got %q", policyType), nil
+	}
+	if resourceType != "" && policyType != "RESOURCE_POLICY" {
+		return result, fmt.Errorf("resource_type may only be set when policy_type is RESOURCE_POLICY"), nil
+	}
+
+	validators := []Validator{localValidator{}}
+	if !skipRemote && !data.Offline {
+		validators = append(validators, remoteValidator{data: data})
+	}
+
+	result, err := chainValidator{validators: validators}.Validate(ctx, policyJSON, policyType, resourceType)
+	if err != nil {
+		return result, nil, err
+	}
+	return result, nil, nil
+}